@@ -8,6 +8,9 @@ import (
 )
 
 func main() {
+	// config-file driven multi-index generation: bypasses every other flag
+	configPath := flag.String("config", "", "Path to a YAML/JSON config file listing multiple generation jobs (bypasses --in/--out/etc.)")
+
 	// required arguments
 	inputPath := flag.String("in", "", "Input JSON schema file (including file name)")
 	outputPath := flag.String("out", "", "Output Go file (including file name)")
@@ -23,11 +26,36 @@ func main() {
 	fieldCommentPath := flag.String("field-comment", "", "Path to JSON file specifying comments for fields")
 	tmplPath := flag.String("tmpl", "", "Path to custom Go template file")
 
+	// live-schema mode: fetch mappings from a running cluster instead of a local file
+	esURL := flag.String("es-url", "", "Base URL of a running Elasticsearch/OpenSearch cluster (enables live-schema mode)")
+	esIndex := flag.String("es-index", "", "Index name or glob to fetch mappings for, e.g. \"logs-*\" (required with --es-url)")
+	esUsername := flag.String("es-username", "", "Basic auth username for --es-url")
+	esPassword := flag.String("es-password", "", "Basic auth password for --es-url")
+	esAPIKey := flag.String("es-api-key", "", "API key for --es-url (takes precedence over basic auth)")
+	esInsecure := flag.Bool("es-insecure", false, "Skip TLS certificate verification when talking to --es-url")
+
+	format := flag.Bool("format", false, "Run generated files through go/format before writing them")
+	verify := flag.Bool("verify", false, "Parse and type-check generated files, failing the run if they don't compile (implies --format)")
+	genQueries := flag.Bool("gen-queries", false, "Also emit a companion file of field-path constants and query-builder helpers")
+	schemaFormat := flag.String("schema-format", "", "Format of the input schema: es (default), jsonschema, or openapi")
+	splitFiles := flag.Bool("split-files", false, "Emit each generated struct as its own file instead of one concatenated file")
+	nestedPackage := flag.String("nested-package", "", "Move nested structs into this package below --out's directory (implies --split-files)")
+
 	flag.Parse()
 
+	if *configPath != "" {
+		runConfig(*configPath)
+		return
+	}
+
+	liveSchema := *esURL != ""
+	if liveSchema && *esIndex == "" {
+		log.Fatalf("--es-index must be specified when --es-url is set")
+	}
+
 	// validate required arguments
-	if *inputPath == "" || *outputPath == "" || *structName == "" || *packageName == "" {
-		log.Fatalf("All --in, --out, --struct, and --package must be specified")
+	if (*inputPath == "" && !liveSchema) || *outputPath == "" || *structName == "" || *packageName == "" {
+		log.Fatalf("All --out, --struct, and --package must be specified, along with either --in or --es-url/--es-index")
 	}
 
 	// set up generator options
@@ -39,6 +67,18 @@ func main() {
 		SkipFieldPath:      nullableString(skipFieldPath),
 		FieldCommentPath:   nullableString(fieldCommentPath),
 		TmplPath:           nullableString(tmplPath),
+		ESURL:              nullableString(esURL),
+		ESIndex:            nullableString(esIndex),
+		ESUsername:         nullableString(esUsername),
+		ESPassword:         nullableString(esPassword),
+		ESAPIKey:           nullableString(esAPIKey),
+		ESInsecure:         *esInsecure,
+		Format:             *format,
+		Verify:             *verify,
+		GenQueries:         *genQueries,
+		SchemaFormat:       nullableString(schemaFormat),
+		SplitFiles:         *splitFiles,
+		NestedPackage:      nullableString(nestedPackage),
 	}
 
 	// generate datamodel
@@ -55,3 +95,25 @@ func nullableString(flagValue *string) *string {
 	}
 	return flagValue
 }
+
+// runConfig runs every job declared in the config file at configPath,
+// reporting each job's outcome without aborting the whole run, then exits
+// non-zero if any job failed.
+func runConfig(configPath string) {
+	results, err := GenerateFromConfigFile(configPath)
+	if err != nil {
+		log.Fatalf("Failed to run config %s: %v", configPath, err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			log.Printf("job %q failed: %v", result.Job.StructName, result.Err)
+		}
+	}
+
+	if failed > 0 {
+		log.Fatalf("%d/%d jobs failed", failed, len(results))
+	}
+}
@@ -0,0 +1,193 @@
+package elasticsearch2go
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile is the shape of an elasticsearch2go config file (YAML or JSON,
+// à la gqlgen.yml): shared type/field/skip/comment maps plus a list of
+// per-index generation jobs. Use GenerateFromConfigFile to run it.
+type ConfigFile struct {
+	Package string `yaml:"package,omitempty" json:"package,omitempty"`
+
+	TypeMappingPath    string `yaml:"typeMapping,omitempty" json:"typeMapping,omitempty"`
+	ExceptionFieldPath string `yaml:"exceptionField,omitempty" json:"exceptionField,omitempty"`
+	ExceptionTypePath  string `yaml:"exceptionType,omitempty" json:"exceptionType,omitempty"`
+	SkipFieldPath      string `yaml:"skipField,omitempty" json:"skipField,omitempty"`
+	FieldCommentPath   string `yaml:"fieldComment,omitempty" json:"fieldComment,omitempty"`
+	TmplPath           string `yaml:"tmpl,omitempty" json:"tmpl,omitempty"`
+
+	Format     bool `yaml:"format,omitempty" json:"format,omitempty"`
+	Verify     bool `yaml:"verify,omitempty" json:"verify,omitempty"`
+	GenQueries bool `yaml:"genQueries,omitempty" json:"genQueries,omitempty"`
+
+	// SchemaFormat is "es" (default), "jsonschema", or "openapi".
+	SchemaFormat string `yaml:"schemaFormat,omitempty" json:"schemaFormat,omitempty"`
+
+	SplitFiles    bool   `yaml:"splitFiles,omitempty" json:"splitFiles,omitempty"`
+	NestedPackage string `yaml:"nestedPackage,omitempty" json:"nestedPackage,omitempty"`
+
+	Jobs []JobConfig `yaml:"jobs" json:"jobs"`
+}
+
+// JobConfig describes one index's generation job within a ConfigFile. Package
+// falls back to ConfigFile.Package when empty. Either InputPath or both
+// ESURL and ESIndex must be set.
+type JobConfig struct {
+	InputPath     string `yaml:"in,omitempty" json:"in,omitempty"`
+	OutputPath    string `yaml:"out" json:"out"`
+	PackageName   string `yaml:"package,omitempty" json:"package,omitempty"`
+	StructName    string `yaml:"struct" json:"struct"`
+	InitClassName string `yaml:"init,omitempty" json:"init,omitempty"`
+
+	ESURL   string `yaml:"esURL,omitempty" json:"esURL,omitempty"`
+	ESIndex string `yaml:"esIndex,omitempty" json:"esIndex,omitempty"`
+}
+
+// JobResult is the outcome of running a single JobConfig from GenerateFromConfigFile.
+type JobResult struct {
+	Job JobConfig
+	Err error
+}
+
+// GenerateFromConfigFile loads a YAML (.yaml/.yml) or JSON (.json) ConfigFile
+// from configPath and runs every job it declares, generating one Go file per
+// job (more with GenQueries, SplitFiles/NestedPackage, or an esIndex glob that
+// matches several indices). Type/field/skip/comment maps are loaded once and
+// shared across all jobs. A job failing does not abort the run: every job's
+// outcome is reported in the returned slice. The returned error is non-nil
+// only if the config file itself could not be loaded, declares no jobs, or
+// its shared maps or template could not be loaded.
+func GenerateFromConfigFile(configPath string) ([]JobResult, error) {
+	cfg, err := loadConfigFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Jobs) == 0 {
+		return nil, fmt.Errorf("config %s declares no jobs", configPath)
+	}
+
+	sharedOpts := &GeneratorOptions{
+		TypeMappingPath:    nonEmptyPtr(cfg.TypeMappingPath),
+		ExceptionFieldPath: nonEmptyPtr(cfg.ExceptionFieldPath),
+		ExceptionTypePath:  nonEmptyPtr(cfg.ExceptionTypePath),
+		SkipFieldPath:      nonEmptyPtr(cfg.SkipFieldPath),
+		FieldCommentPath:   nonEmptyPtr(cfg.FieldCommentPath),
+		TmplPath:           nonEmptyPtr(cfg.TmplPath),
+		Format:             cfg.Format,
+		Verify:             cfg.Verify,
+		GenQueries:         cfg.GenQueries,
+		SchemaFormat:       nonEmptyPtr(cfg.SchemaFormat),
+		SplitFiles:         cfg.SplitFiles,
+		NestedPackage:      nonEmptyPtr(cfg.NestedPackage),
+	}
+
+	gen, err := NewGenerator(sharedOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build shared generator for %s: %w", configPath, err)
+	}
+
+	var sharedTmpl *template.Template
+	if cfg.TmplPath != "" {
+		sharedTmpl, err = loadTemplate(sharedOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]JobResult, 0, len(cfg.Jobs))
+	for _, job := range cfg.Jobs {
+		results = append(results, JobResult{
+			Job: job,
+			Err: runJob(gen, job, cfg, sharedTmpl),
+		})
+	}
+
+	return results, nil
+}
+
+func runJob(gen *Generator, job JobConfig, cfg ConfigFile, sharedTmpl *template.Template) error {
+	packageName := job.PackageName
+	if packageName == "" {
+		packageName = cfg.Package
+	}
+
+	tmpl := sharedTmpl
+	if tmpl == nil {
+		var err error
+		tmpl, err = defaultTemplate(job.InitClassName)
+		if err != nil {
+			return err
+		}
+	}
+
+	if job.ESURL != "" && job.ESIndex != "" {
+		esOpts := &GeneratorOptions{
+			ESURL:         &job.ESURL,
+			ESIndex:       &job.ESIndex,
+			Format:        cfg.Format,
+			Verify:        cfg.Verify,
+			GenQueries:    cfg.GenQueries,
+			SplitFiles:    cfg.SplitFiles,
+			NestedPackage: nonEmptyPtr(cfg.NestedPackage),
+		}
+		return generateFromElasticsearch(gen, job.OutputPath, packageName, job.StructName, job.InitClassName, tmpl, esOpts)
+	}
+
+	if job.InputPath == "" {
+		return fmt.Errorf("job %q: either \"in\" or \"esURL\"/\"esIndex\" must be set", job.StructName)
+	}
+
+	data, err := os.ReadFile(job.InputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", job.InputPath, err)
+	}
+
+	return generateToFile(gen, job.OutputPath, Config{
+		PackageName:   packageName,
+		StructName:    job.StructName,
+		InitClassName: job.InitClassName,
+		Template:      tmpl,
+		Format:        cfg.Format,
+		Verify:        cfg.Verify,
+		GenQueries:    cfg.GenQueries,
+		SchemaFormat:  SchemaFormat(cfg.SchemaFormat),
+		SplitFiles:    cfg.SplitFiles,
+		NestedPackage: cfg.NestedPackage,
+	}, bytes.NewReader(data))
+}
+
+func loadConfigFile(configPath string) (ConfigFile, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return ConfigFile{}, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	var cfg ConfigFile
+	if filepath.Ext(configPath) == ".json" {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return ConfigFile{}, fmt.Errorf("error unmarshalling JSON config %s: %w", configPath, err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return ConfigFile{}, fmt.Errorf("error unmarshalling YAML config %s: %w", configPath, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+func nonEmptyPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,196 @@
+package elasticsearch2go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// GeneratorOptions holds every optional knob accepted by GenerateDatamodel.
+// String fields are pointers so that nil can mark an option as not set;
+// boolean fields default to false.
+type GeneratorOptions struct {
+	InitClassName      *string
+	TypeMappingPath    *string
+	ExceptionFieldPath *string
+	ExceptionTypePath  *string
+	SkipFieldPath      *string
+	FieldCommentPath   *string
+	TmplPath           *string
+
+	// Live-schema mode: when ESURL and ESIndex are both set, GenerateDatamodel
+	// fetches mappings from a running Elasticsearch/OpenSearch cluster instead
+	// of reading InputPath from disk.
+	ESURL      *string
+	ESIndex    *string
+	ESUsername *string
+	ESPassword *string
+	ESAPIKey   *string
+	ESInsecure bool
+
+	// Format runs generated files through go/format before writing them.
+	Format bool
+	// Verify additionally parses and type-checks generated files, failing
+	// the run if a template produced invalid or non-compiling Go.
+	Verify bool
+
+	// GenQueries additionally emits a companion file of field-path constants
+	// and Elasticsearch query-builder helpers for the generated struct.
+	GenQueries bool
+
+	// SchemaFormat selects how inputPath is parsed: "es" (default),
+	// "jsonschema" or "openapi". Ignored in live-schema mode, which always
+	// reads an Elasticsearch mapping.
+	SchemaFormat *string
+
+	// SplitFiles emits each generated struct as its own file instead of
+	// concatenating them into one.
+	SplitFiles bool
+	// NestedPackage, if set, additionally moves every nested struct into its
+	// own package below outputPath's directory; implies SplitFiles.
+	NestedPackage *string
+}
+
+// GenerateDatamodel generates a Go source file (or, in live-schema / multi-index
+// mode, several files) describing the given Elasticsearch mapping as Go structs,
+// and writes it to outputPath. It is the file-oriented counterpart to the
+// Generator/Generate API: use that instead when embedding elasticsearch2go in
+// another program.
+//
+// When opts.ESURL and opts.ESIndex are set, the mapping is fetched from a live
+// cluster instead of being read from inputPath; otherwise inputPath must point
+// to a local JSON mapping file.
+func GenerateDatamodel(inputPath, outputPath, packageName, structName string, opts *GeneratorOptions) error {
+	if opts == nil {
+		opts = &GeneratorOptions{}
+	}
+
+	gen, err := NewGenerator(opts)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := loadTemplate(opts)
+	if err != nil {
+		return err
+	}
+
+	initClassName := ""
+	if opts.InitClassName != nil {
+		initClassName = *opts.InitClassName
+	}
+
+	if opts.ESURL != nil && opts.ESIndex != nil {
+		return generateFromElasticsearch(gen, outputPath, packageName, structName, initClassName, tmpl, opts)
+	}
+
+	if inputPath == "" {
+		return fmt.Errorf("either --in or --es-url/--es-index must be specified")
+	}
+
+	data, err := os.ReadFile(inputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", inputPath, err)
+	}
+
+	return generateToFile(gen, outputPath, Config{
+		PackageName:   packageName,
+		StructName:    structName,
+		InitClassName: initClassName,
+		Template:      tmpl,
+		Format:        opts.Format,
+		Verify:        opts.Verify,
+		GenQueries:    opts.GenQueries,
+		SchemaFormat:  schemaFormatFromOpts(opts),
+		SplitFiles:    opts.SplitFiles,
+		NestedPackage: nestedPackageFromOpts(opts),
+	}, bytes.NewReader(data))
+}
+
+func schemaFormatFromOpts(opts *GeneratorOptions) SchemaFormat {
+	if opts.SchemaFormat == nil {
+		return ""
+	}
+	return SchemaFormat(*opts.SchemaFormat)
+}
+
+func nestedPackageFromOpts(opts *GeneratorOptions) string {
+	if opts.NestedPackage == nil {
+		return ""
+	}
+	return *opts.NestedPackage
+}
+
+func loadTemplate(opts *GeneratorOptions) (*template.Template, error) {
+	if opts.TmplPath != nil && *opts.TmplPath != "" {
+		tmpl, err := template.ParseFiles(*opts.TmplPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load template file %s: %w", *opts.TmplPath, err)
+		}
+		return tmpl, nil
+	}
+
+	initClassName := ""
+	if opts.InitClassName != nil {
+		initClassName = *opts.InitClassName
+	}
+	return defaultTemplate(initClassName)
+}
+
+// generateToFile runs gen.Generate against r and writes the result to
+// outputPath, creating parent directories as needed. When Generate produces
+// companion files (e.g. cfg.GenQueries), the first is written to outputPath
+// and the rest are written under their own suggested names relative to
+// outputPath's directory (a cfg.NestedPackage file's name includes that
+// package's subdirectory).
+func generateToFile(gen *Generator, outputPath string, cfg Config, r *bytes.Reader) error {
+	files, err := gen.Generate(context.Background(), r, cfg)
+	if err != nil {
+		return err
+	}
+
+	outDir := filepath.Dir(outputPath)
+	for i, f := range files {
+		dest := outputPath
+		if i > 0 {
+			dest = filepath.Join(outDir, f.Name)
+		}
+		if err := writeGeneratedFile(dest, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeGeneratedFile(outputPath string, f GeneratedFile) error {
+	if dir := filepath.Dir(outputPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory %s: %w", dir, err)
+		}
+	}
+
+	if err := os.WriteFile(outputPath, f.Source, 0644); err != nil {
+		return fmt.Errorf("failed to write output file %s: %w", outputPath, err)
+	}
+
+	fmt.Printf("Generated Go struct %s and saved to %s\n", f.StructName, outputPath)
+	return nil
+}
+
+func loadJSONFileInto(filePath string, target interface{}) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("error unmarshalling JSON from %s: %w", filePath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package elasticsearch2go
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// esIndexMappingResponse mirrors the shape of GET /<index>/_mapping, which
+// returns one top-level key per matched index (globs expand server-side).
+type esIndexMappingResponse map[string]ElasticsearchMapping
+
+// fetchMappings pulls index mappings from a running Elasticsearch/OpenSearch
+// cluster via GET /<index>/_mapping, returning one Mappings per matched index.
+func fetchMappings(opts *GeneratorOptions) (map[string]Mappings, error) {
+	baseURL := strings.TrimRight(*opts.ESURL, "/")
+	index := *opts.ESIndex
+
+	reqURL := fmt.Sprintf("%s/%s/_mapping", baseURL, index)
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s: %w", reqURL, err)
+	}
+
+	if opts.ESAPIKey != nil && *opts.ESAPIKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+*opts.ESAPIKey)
+	} else if opts.ESUsername != nil && *opts.ESUsername != "" {
+		password := ""
+		if opts.ESPassword != nil {
+			password = *opts.ESPassword
+		}
+		req.SetBasicAuth(*opts.ESUsername, password)
+	}
+
+	client := &http.Client{}
+	if opts.ESInsecure {
+		client.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch mappings from %s: %w", reqURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body from %s: %w", reqURL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s: %s", resp.StatusCode, reqURL, string(body))
+	}
+
+	var raw esIndexMappingResponse
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("error unmarshalling mapping response from %s: %w", reqURL, err)
+	}
+
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("no indices matched %q on %s", index, baseURL)
+	}
+
+	mappings := make(map[string]Mappings, len(raw))
+	for indexName, esMapping := range raw {
+		mappings[indexName] = esMapping.Mappings
+	}
+
+	return mappings, nil
+}
+
+// generateFromElasticsearch fetches mappings for opts.ESIndex (glob supported)
+// and writes the result to disk using gen. When exactly one index matches, the
+// caller-supplied structName and outputPath are used as-is; otherwise
+// outputPath (or, if it names a .go file, its directory) is used as the
+// output directory and each index gets its own file named after it, with a
+// struct named after the index.
+func generateFromElasticsearch(gen *Generator, outputPath, packageName, structName, initClassName string, tmpl *template.Template, opts *GeneratorOptions) error {
+	mappings, err := fetchMappings(opts)
+	if err != nil {
+		return err
+	}
+
+	if len(mappings) == 1 {
+		for _, m := range mappings {
+			return generateToFile(gen, outputPath, Config{
+				PackageName:   packageName,
+				StructName:    structName,
+				InitClassName: initClassName,
+				Template:      tmpl,
+				Format:        opts.Format,
+				Verify:        opts.Verify,
+				GenQueries:    opts.GenQueries,
+				SplitFiles:    opts.SplitFiles,
+				NestedPackage: nestedPackageFromOpts(opts),
+			}, mappingReader(m))
+		}
+	}
+
+	outDir := outputPath
+	if ext := filepath.Ext(outDir); ext == ".go" {
+		outDir = filepath.Dir(outDir)
+	}
+
+	for indexName, mapping := range mappings {
+		indexStructName := toPascalCase(sanitizeIndexName(indexName))
+		indexOutputPath := filepath.Join(outDir, sanitizeIndexName(indexName)+".go")
+
+		err := generateToFile(gen, indexOutputPath, Config{
+			PackageName:   packageName,
+			StructName:    indexStructName,
+			InitClassName: initClassName,
+			Template:      tmpl,
+			Format:        opts.Format,
+			Verify:        opts.Verify,
+			GenQueries:    opts.GenQueries,
+			SplitFiles:    opts.SplitFiles,
+			NestedPackage: nestedPackageFromOpts(opts),
+		}, mappingReader(mapping))
+		if err != nil {
+			return fmt.Errorf("failed to generate struct for index %s: %w", indexName, err)
+		}
+	}
+
+	return nil
+}
+
+// mappingReader re-wraps a Mappings value as the JSON document Generate
+// expects, since fetchMappings has already unwrapped the ES response envelope.
+func mappingReader(m Mappings) *bytes.Reader {
+	data, _ := json.Marshal(ElasticsearchMapping{Mappings: m})
+	return bytes.NewReader(data)
+}
+
+// sanitizeIndexName turns an Elasticsearch index name (which may contain
+// dashes or dots, e.g. "logs-2024.01") into a Go-identifier-friendly form.
+func sanitizeIndexName(indexName string) string {
+	replacer := strings.NewReplacer("-", "_", ".", "_")
+	return replacer.Replace(indexName)
+}
@@ -0,0 +1,17 @@
+package elasticsearch2go
+
+import "testing"
+
+func TestSanitizeIndexName(t *testing.T) {
+	cases := map[string]string{
+		"logs-2024.01": "logs_2024_01",
+		"orders":       "orders",
+		"a-b.c-d":      "a_b_c_d",
+	}
+
+	for in, want := range cases {
+		if got := sanitizeIndexName(in); got != want {
+			t.Errorf("sanitizeIndexName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
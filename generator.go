@@ -0,0 +1,655 @@
+// Package elasticsearch2go generates Go struct definitions (and, optionally,
+// query-builder helpers) from Elasticsearch/OpenSearch index mappings.
+package elasticsearch2go
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+type Property struct {
+	Type       string              `json:"type"`
+	Properties map[string]Property `json:"properties,omitempty"`
+
+	// GoType, Enum and MapValueGoType are populated by non-Elasticsearch
+	// SchemaLoaders (see schema.go) whose source type systems don't map onto
+	// GoTypeMap's Elasticsearch vocabulary. When GoType is set it is used
+	// as-is instead of consulting GoTypeMap/TypeExceptions.
+	GoType         string   `json:"-"`
+	Enum           []string `json:"-"`
+	MapValueGoType string   `json:"-"`
+
+	// Required is populated by SchemaLoaders whose source format has an
+	// explicit required/optional distinction (JSON Schema, OpenAPI; see
+	// schema.go). Elasticsearch mappings have no such concept, so it is
+	// always false for ES-sourced properties. A required nested object or
+	// array field is emitted without the pointer generateStruct otherwise
+	// uses to represent "may be absent".
+	Required bool `json:"-"`
+
+	// Slice marks a Type == "object"/"nested" Property whose source was
+	// actually a JSON Schema/OpenAPI array of objects (see schema.go), so
+	// generateStruct renders the field as "[]Name" instead of treating it
+	// like a single embedded object.
+	Slice bool `json:"-"`
+
+	// Map marks a Type == "object" Property whose source was a JSON Schema
+	// additionalProperties map (see schema.go). generateStruct renders it as
+	// "map[string]T": T is MapValueGoType when the map's value has no fixed
+	// shape, or a generated struct (from Properties, via the same shape-dedup
+	// path as an ordinary nested object) when its value is itself an object
+	// with concrete fields.
+	Map bool `json:"-"`
+}
+
+type Mappings struct {
+	Properties map[string]Property `json:"properties"`
+}
+
+type ElasticsearchMapping struct {
+	Mappings Mappings `json:"mappings"`
+}
+
+const structTemplateWithWrapper = `package {{.PackageName}}
+
+type {{.InitClassName}} struct {
+	{{.StructName}}
+}
+
+{{.StructDefinitions}}
+`
+
+const structTemplateWithoutWrapper = `package {{.PackageName}}
+
+{{.StructDefinitions}}
+`
+
+type Field struct {
+	FieldName    string
+	FieldType    string
+	JSONName     string
+	FieldComment string
+}
+
+type StructData struct {
+	PackageName       string
+	InitClassName     string
+	StructName        string
+	StructDefinitions string
+}
+
+func defaultGoTypeMap() map[string]string {
+	return map[string]string{
+		"integer":   "*uint64",
+		"float":     "*float64",
+		"boolean":   "bool",
+		"text":      "*string",
+		"keyword":   "*string",
+		"date":      "*time.Time",
+		"geo_point": "*GeoPoint",
+		"object":    "*map[string]interface{}",
+		"nested":    "[]interface{}",
+	}
+}
+
+// Generator holds the type/field/skip/comment maps used to turn an
+// Elasticsearch mapping into Go structs. Unlike the package-level globals it
+// replaces, a Generator's maps are populated once at construction time and
+// only read afterwards, so a single Generator can be shared safely across
+// concurrent Generate calls (e.g. from multiple goroutines, or repeated
+// go:generate invocations in the same process).
+type Generator struct {
+	goTypeMap       map[string]string
+	fieldExceptions map[string]string
+	typeExceptions  map[string]string
+	skipFields      map[string]bool
+	fieldComments   map[string]string
+}
+
+// NewGenerator builds a Generator from the type/field/skip/comment maps in
+// opts. A nil opts is equivalent to &GeneratorOptions{}, i.e. default type
+// mapping and no exceptions.
+func NewGenerator(opts *GeneratorOptions) (*Generator, error) {
+	if opts == nil {
+		opts = &GeneratorOptions{}
+	}
+
+	g := &Generator{
+		goTypeMap:       defaultGoTypeMap(),
+		fieldExceptions: map[string]string{},
+		typeExceptions:  map[string]string{},
+		skipFields:      map[string]bool{},
+		fieldComments:   map[string]string{},
+	}
+
+	if opts.TypeMappingPath != nil && *opts.TypeMappingPath != "" {
+		if err := loadJSONFileInto(*opts.TypeMappingPath, &g.goTypeMap); err != nil {
+			return nil, fmt.Errorf("failed to load type mapping: %w", err)
+		}
+	}
+	if opts.ExceptionFieldPath != nil && *opts.ExceptionFieldPath != "" {
+		if err := loadJSONFileInto(*opts.ExceptionFieldPath, &g.fieldExceptions); err != nil {
+			return nil, fmt.Errorf("failed to load field exceptions: %w", err)
+		}
+	}
+	if opts.ExceptionTypePath != nil && *opts.ExceptionTypePath != "" {
+		if err := loadJSONFileInto(*opts.ExceptionTypePath, &g.typeExceptions); err != nil {
+			return nil, fmt.Errorf("failed to load type exceptions: %w", err)
+		}
+	}
+	if opts.SkipFieldPath != nil && *opts.SkipFieldPath != "" {
+		if err := loadJSONFileInto(*opts.SkipFieldPath, &g.skipFields); err != nil {
+			return nil, fmt.Errorf("failed to load skip fields: %w", err)
+		}
+	}
+	if opts.FieldCommentPath != nil && *opts.FieldCommentPath != "" {
+		if err := loadJSONFileInto(*opts.FieldCommentPath, &g.fieldComments); err != nil {
+			return nil, fmt.Errorf("failed to load field comments: %w", err)
+		}
+	}
+
+	return g, nil
+}
+
+// Config describes a single struct-generation job against an already-loaded
+// Generator: the package/struct names to emit and, optionally, a wrapper
+// struct name or custom template.
+type Config struct {
+	PackageName   string
+	StructName    string
+	InitClassName string
+	Template      *template.Template
+
+	// Format runs the rendered source through go/format, as gofmt would.
+	Format bool
+	// Verify additionally parses and type-checks the (optionally formatted)
+	// source, failing Generate if the template produced invalid or
+	// non-compiling Go.
+	Verify bool
+
+	// GenQueries additionally emits a companion file of field-path constants
+	// and Elasticsearch query-builder helpers for the generated struct.
+	GenQueries bool
+
+	// SchemaFormat selects how the input document is parsed. The zero value
+	// (SchemaFormatElasticsearch) reads an Elasticsearch/OpenSearch mapping.
+	SchemaFormat SchemaFormat
+
+	// SplitFiles emits each generated struct (the top-level one and every
+	// nested one) as its own GeneratedFile instead of concatenating them
+	// into a single file.
+	SplitFiles bool
+	// NestedPackage, if set, additionally moves every nested struct into its
+	// own package: nested files get `package NestedPackage` and SplitFiles is
+	// implied. Field types referencing a moved struct are qualified as
+	// NestedPackage.TypeName; as with this package's existing *time.Time /
+	// *GeoPoint references, the caller is responsible for importing
+	// NestedPackage wherever that reference is used.
+	NestedPackage string
+}
+
+// GeneratedFile is one rendered Go source file produced by Generate.
+type GeneratedFile struct {
+	// Name is a suggested file name, e.g. "my_index.go". It is derived from
+	// StructName and is not written to disk by Generate itself.
+	Name       string
+	StructName string
+	Source     []byte
+}
+
+// Generate reads a schema document from r (an Elasticsearch mapping unless
+// cfg.SchemaFormat says otherwise) and renders it into
+// Go source according to cfg, without touching the filesystem. It is the
+// programmatic equivalent of the elasticsearch2go CLI and is safe to call
+// from go:generate directives, tests, or larger codegen pipelines.
+func (g *Generator) Generate(ctx context.Context, r io.Reader, cfg Config) ([]GeneratedFile, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping: %w", err)
+	}
+
+	loader, err := loaderForFormat(cfg.SchemaFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, err := loader.Load(data, cfg.StructName)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := cfg.Template
+	if tmpl == nil {
+		tmpl, err = defaultTemplate(cfg.InitClassName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	structNameTracker := make(map[string]bool)
+	shapes := make(map[string]string)
+	structDefinitions := g.generateStructDefinitions(structNameTracker, shapes, cfg.StructName, mappings.Properties)
+
+	var files []GeneratedFile
+	if cfg.SplitFiles || cfg.NestedPackage != "" {
+		files, err = renderSplitFiles(tmpl, cfg, structDefinitions)
+	} else {
+		files, err = renderSingleFile(tmpl, cfg, structDefinitions)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.GenQueries {
+		queryFile, err := g.generateQueryHelpers(cfg, mappings.Properties)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, queryFile)
+	}
+
+	return files, nil
+}
+
+func renderSingleFile(tmpl *template.Template, cfg Config, structDefinitions string) ([]GeneratedFile, error) {
+	structData := StructData{
+		PackageName:       cfg.PackageName,
+		InitClassName:     cfg.InitClassName,
+		StructName:        cfg.StructName,
+		StructDefinitions: structDefinitions,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, structData); err != nil {
+		return nil, fmt.Errorf("error executing template: %w", err)
+	}
+
+	source, err := postProcess(buf.Bytes(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return []GeneratedFile{{
+		Name:       fileNameForStruct(cfg.StructName),
+		StructName: cfg.StructName,
+		Source:     source,
+	}}, nil
+}
+
+// renderSplitFiles emits one file per top-level type declaration: the
+// top-level struct (through cfg.Template, so wrapper/custom templates still
+// apply to it) and every nested struct or enum (through the plain,
+// no-wrapper template). When cfg.NestedPackage is set, nested files declare
+// that package and field references to them are qualified accordingly.
+func renderSplitFiles(tmpl *template.Template, cfg Config, structDefinitions string) ([]GeneratedFile, error) {
+	blocks := splitStructBlocks(structDefinitions)
+	if len(blocks) == 0 {
+		return renderSingleFile(tmpl, cfg, structDefinitions)
+	}
+
+	nestedPackage := cfg.NestedPackage
+	if nestedPackage != "" {
+		nestedNames := make([]string, len(blocks)-1)
+		for i, b := range blocks[1:] {
+			nestedNames[i] = b.Name
+		}
+		qualifyNestedReferences(blocks, nestedNames, nestedPackage)
+	}
+
+	nestedTmpl, err := template.New("nested").Parse(structTemplateWithoutWrapper)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing nested struct template: %w", err)
+	}
+
+	files := make([]GeneratedFile, 0, len(blocks))
+	for i, block := range blocks {
+		packageName := cfg.PackageName
+		t := tmpl
+		structName := cfg.StructName
+		initClassName := cfg.InitClassName
+		if i > 0 {
+			if nestedPackage != "" {
+				packageName = nestedPackage
+			}
+			t = nestedTmpl
+			structName = block.Name
+			initClassName = ""
+		}
+
+		var buf bytes.Buffer
+		err := t.Execute(&buf, StructData{
+			PackageName:       packageName,
+			InitClassName:     initClassName,
+			StructName:        structName,
+			StructDefinitions: block.Source,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error executing template for %s: %w", block.Name, err)
+		}
+
+		source, err := postProcess(buf.Bytes(), cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		name := fileNameForStruct(block.Name)
+		if i > 0 && nestedPackage != "" {
+			name = nestedPackage + "/" + name
+		}
+
+		files = append(files, GeneratedFile{Name: name, StructName: block.Name, Source: source})
+	}
+
+	return files, nil
+}
+
+func postProcess(source []byte, cfg Config) ([]byte, error) {
+	if cfg.Format || cfg.Verify {
+		formatted, err := formatSource(source)
+		if err != nil {
+			return nil, err
+		}
+		source = formatted
+	}
+
+	if cfg.Verify {
+		if err := verifySource(source); err != nil {
+			return nil, err
+		}
+	}
+
+	return source, nil
+}
+
+// GenerateTo is Generate followed by writing the single resulting file's
+// source to w. It returns an error if Generate produces anything other than
+// exactly one file.
+func (g *Generator) GenerateTo(ctx context.Context, w io.Writer, r io.Reader, cfg Config) error {
+	files, err := g.Generate(ctx, r, cfg)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 {
+		return fmt.Errorf("GenerateTo expects exactly one generated file, got %d", len(files))
+	}
+
+	_, err = w.Write(files[0].Source)
+	return err
+}
+
+func defaultTemplate(initClassName string) (*template.Template, error) {
+	if initClassName != "" {
+		return template.New("structWithWrapper").Parse(structTemplateWithWrapper)
+	}
+	return template.New("structWithoutWrapper").Parse(structTemplateWithoutWrapper)
+}
+
+func fileNameForStruct(structName string) string {
+	return toSnakeCase(structName) + ".go"
+}
+
+// generateStructDefinitions renders structName and, recursively, every
+// nested struct it contains. shapes maps a nested object's canonicalSignature
+// to the name of the struct already generated for it, so that two nested
+// objects with identical shapes (e.g. an "order.user" and a "comment.user"
+// with the same fields) share one generated type instead of each minting its
+// own; tracker is keyed by struct name and prevents re-emitting the same
+// struct twice.
+func (g *Generator) generateStructDefinitions(tracker map[string]bool, shapes map[string]string, structName string, properties map[string]Property) string {
+	var structDefs strings.Builder
+
+	g.generateStruct(tracker, shapes, &structDefs, structName, properties)
+
+	return structDefs.String()
+}
+
+func (g *Generator) generateStruct(tracker map[string]bool, shapes map[string]string, structDefs *strings.Builder, structName string, properties map[string]Property) {
+	// check if the struct has already been generated
+	if _, exists := tracker[structName]; exists {
+		return
+	}
+
+	// mark this struct as generated
+	tracker[structName] = true
+
+	fields := []Field{}
+	nestedStructs := []string{}
+
+	// iterate in a stable order: map iteration order is randomized by the
+	// runtime, and which field first claims a given canonicalSignature in
+	// shapes decides that shared struct's name, so an unsorted walk makes
+	// naming (and thus the generated source) non-deterministic across runs.
+	propertyNames := make([]string, 0, len(properties))
+	for name := range properties {
+		propertyNames = append(propertyNames, name)
+	}
+	sort.Strings(propertyNames)
+
+	for _, name := range propertyNames {
+		prop := properties[name]
+		// skip fields that are in the skipFields map
+		if _, skip := g.skipFields[name]; skip {
+			continue
+		}
+
+		fieldName := g.mapElasticsearchFieldToGoField(name)
+		var fieldType string
+
+		switch {
+		case len(prop.Enum) > 0:
+			enumTypeName := structName + fieldName
+			fieldType = enumTypeName
+			nestedStructs = append(nestedStructs, generateEnumType(enumTypeName, prop.Enum))
+		case prop.Type == "object" || prop.Type == "nested":
+			if prop.Map && len(prop.Properties) == 0 {
+				// a JSON Schema additionalProperties map with no fixed value shape
+				fieldType = "map[string]" + prop.MapValueGoType
+			} else if prop.Map {
+				// additionalProperties whose value is itself an object with
+				// concrete fields: generate a struct for it, same as an
+				// ordinary nested object, and key it by map[string]
+				if existingName, ok := shapes[canonicalSignature(prop.Properties)]; ok {
+					fieldType = "map[string]" + existingName
+				} else {
+					nestedStructName := structName + toPascalCase(name)
+					fieldType = "map[string]" + nestedStructName
+					shapes[canonicalSignature(prop.Properties)] = nestedStructName
+					nestedStructs = append(nestedStructs, g.generateStructDefinitions(tracker, shapes, nestedStructName, prop.Properties))
+				}
+			} else if customType, exists := g.typeExceptions[name]; exists {
+				// check if the type has a custom exception
+				var nestedStructName string
+				fieldType = customType
+				if strings.HasPrefix(fieldType, "*") {
+					nestedStructName = fieldType[1:]
+				} else if strings.HasPrefix(fieldType, "[]") {
+					nestedStructName = fieldType[2:]
+				} else {
+					nestedStructName = fieldType
+				}
+				nestedStructs = append(nestedStructs, g.generateStructDefinitions(tracker, shapes, nestedStructName, prop.Properties))
+			} else if existingName, ok := shapes[canonicalSignature(prop.Properties)]; ok {
+				// an earlier nested object at a different path already has
+				// this exact shape; reuse its struct instead of minting a
+				// duplicate with a new name
+				fieldType = nestedFieldType(existingName, prop.Required, prop.Slice)
+			} else {
+				nestedStructName := structName + toPascalCase(name)
+				fieldType = nestedFieldType(nestedStructName, prop.Required, prop.Slice)
+				shapes[canonicalSignature(prop.Properties)] = nestedStructName
+				nestedStructs = append(nestedStructs, g.generateStructDefinitions(tracker, shapes, nestedStructName, prop.Properties))
+			}
+		default:
+			fieldType = g.mapElasticsearchTypeToGoType(name, prop)
+		}
+
+		fieldComment := g.mapElasticsearchFieldToComment(name)
+
+		fields = append(fields, Field{
+			FieldName:    fieldName,
+			FieldType:    fieldType,
+			JSONName:     name,
+			FieldComment: fieldComment,
+		})
+	}
+
+	// sort fields alphabetically
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].FieldName < fields[j].FieldName
+	})
+
+	// generate struct definition
+	structDefs.WriteString(fmt.Sprintf("type %s struct {\n", structName))
+	for _, field := range fields {
+		if field.FieldComment != "" {
+			structDefs.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"` // %s\n", field.FieldName, field.FieldType, field.JSONName, field.FieldComment))
+		} else {
+			structDefs.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"`\n", field.FieldName, field.FieldType, field.JSONName))
+		}
+	}
+	structDefs.WriteString("}\n\n")
+
+	// append nested structs
+	for _, nestedStruct := range nestedStructs {
+		structDefs.WriteString(nestedStruct)
+	}
+}
+
+func (g *Generator) mapElasticsearchTypeToGoType(name string, prop Property) string {
+	// non-Elasticsearch SchemaLoaders resolve their own Go type directly,
+	// since GoTypeMap's vocabulary doesn't apply to them
+	if prop.GoType != "" {
+		return prop.GoType
+	}
+
+	// check if the type has a custom exception
+	if customType, exists := g.typeExceptions[name]; exists {
+		return customType
+	}
+
+	goType, exists := g.goTypeMap[prop.Type]
+	if !exists {
+		goType = "interface{}"
+	}
+
+	return goType
+}
+
+// generateEnumType renders a named string type and its const block for a
+// JSON Schema / OpenAPI enum field, e.g.:
+//
+//	type UserStatus string
+//
+//	const (
+//		UserStatusActive UserStatus = "active"
+//	)
+func generateEnumType(typeName string, values []string) string {
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("type %s string\n\n", typeName))
+	out.WriteString("const (\n")
+	for _, value := range values {
+		out.WriteString(fmt.Sprintf("\t%s%s %s = %q\n", typeName, toPascalCase(sanitizeIdentifier(value)), typeName, value))
+	}
+	out.WriteString(")\n\n")
+	return out.String()
+}
+
+// sanitizeIdentifier replaces characters toPascalCase doesn't already split
+// on (spaces, dashes, ...) with underscores so enum values like "in-progress"
+// or "not started" become valid Go identifier parts.
+func sanitizeIdentifier(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+func (g *Generator) mapElasticsearchFieldToGoField(esFieldName string) string {
+	// check if the field has a custom exception
+	if customFieldName, exists := g.fieldExceptions[esFieldName]; exists {
+		return customFieldName
+	}
+
+	return toPascalCase(esFieldName)
+}
+
+func (g *Generator) mapElasticsearchFieldToComment(esFieldName string) string {
+	// check if the field has a custom comment
+	if comment, exists := g.fieldComments[esFieldName]; exists {
+		return comment
+	}
+
+	return ""
+}
+
+func toCamelCase(s string) string {
+	caser := cases.Title(language.Und) // or: `language.English`
+	parts := strings.Split(s, "_")
+	for i, part := range parts {
+		parts[i] = caser.String(part)
+	}
+	parts[0] = strings.ToLower(parts[0])
+	return strings.Join(parts, "")
+}
+
+// nestedFieldType returns the Go field type referencing nestedStructName. A
+// slice field (a JSON Schema/OpenAPI array of objects) is rendered as
+// "[]Name": a nil slice already represents "absent", so required is
+// irrelevant. Otherwise the field is a pointer, unless required reports the
+// field is required (JSON Schema, OpenAPI), in which case the field is
+// always present and the pointer is unnecessary.
+func nestedFieldType(nestedStructName string, required, slice bool) string {
+	if slice {
+		return "[]" + nestedStructName
+	}
+	if required {
+		return nestedStructName
+	}
+	return "*" + nestedStructName
+}
+
+func toPascalCase(s string) string {
+	caser := cases.Title(language.Und)
+	parts := strings.Split(s, "_")
+	for i, part := range parts {
+		parts[i] = caser.String(part)
+	}
+	return strings.Join(parts, "")
+}
+
+func toSnakeCase(s string) string {
+	var out strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				out.WriteByte('_')
+			}
+			out.WriteRune(r - 'A' + 'a')
+		} else {
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
+
+// GeoPoint struct for handling geo_point type in Elasticsearch
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
@@ -0,0 +1,56 @@
+package elasticsearch2go
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestGeneratorGenerateProgrammaticAPI exercises the in-memory Generate API
+// end to end: a Generator built once and reused for several Generate calls,
+// including concurrently, as the doc comment on Generator promises.
+func TestGeneratorGenerateProgrammaticAPI(t *testing.T) {
+	mapping := []byte(`{
+		"mappings": {
+			"properties": {
+				"id": {"type": "keyword"},
+				"age": {"type": "integer"}
+			}
+		}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			files, err := gen.Generate(context.Background(), bytes.NewReader(mapping), Config{
+				PackageName: "model",
+				StructName:  "Account",
+			})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if len(files) != 1 || !strings.Contains(string(files[0].Source), "type Account struct") {
+				errs[i] = fmt.Errorf("got %d files, want 1 containing \"type Account struct\"", len(files))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("concurrent Generate call %d failed: %v", i, err)
+		}
+	}
+}
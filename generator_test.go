@@ -0,0 +1,66 @@
+package elasticsearch2go
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// TestGenerateDeterministicNestedNaming guards against regressing to
+// map-iteration-order-dependent naming of shared nested structs: the same
+// mapping must always produce byte-identical source, not just on average.
+func TestGenerateDeterministicNestedNaming(t *testing.T) {
+	mapping := []byte(`{
+		"mappings": {
+			"properties": {
+				"user": {
+					"type": "object",
+					"properties": {
+						"id": {"type": "keyword"},
+						"name": {"type": "text"}
+					}
+				},
+				"order": {
+					"type": "object",
+					"properties": {
+						"user": {
+							"type": "object",
+							"properties": {
+								"id": {"type": "keyword"},
+								"name": {"type": "text"}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	var want []byte
+	for i := 0; i < 50; i++ {
+		files, err := gen.Generate(context.Background(), bytes.NewReader(mapping), Config{
+			PackageName: "model",
+			StructName:  "Root",
+		})
+		if err != nil {
+			t.Fatalf("Generate (run %d): %v", i, err)
+		}
+		if len(files) != 1 {
+			t.Fatalf("Generate (run %d): got %d files, want 1", i, len(files))
+		}
+
+		if want == nil {
+			want = files[0].Source
+			continue
+		}
+
+		if !bytes.Equal(files[0].Source, want) {
+			t.Fatalf("Generate is non-deterministic: run %d produced different source than run 0\nrun0:\n%s\nrun%d:\n%s", i, want, i, files[0].Source)
+		}
+	}
+}
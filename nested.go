@@ -0,0 +1,111 @@
+package elasticsearch2go
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// canonicalSignature returns a deterministic string describing the shape of
+// properties (field names, types, and recursively nested shapes). Two
+// Property maps with the same signature produce identical Go structs, so
+// generateStruct uses it to recognize that a nested object appearing at two
+// different paths (e.g. an "order.user" and a "comment.user" with the same
+// fields) can share one generated type instead of each getting its own
+// separately-named struct.
+func canonicalSignature(properties map[string]Property) string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sig strings.Builder
+	for _, name := range names {
+		prop := properties[name]
+		fmt.Fprintf(&sig, "%s:%s", name, prop.Type)
+		if prop.GoType != "" {
+			fmt.Fprintf(&sig, "=%s", prop.GoType)
+		}
+		if prop.MapValueGoType != "" {
+			fmt.Fprintf(&sig, "=map[string]%s", prop.MapValueGoType)
+		}
+		if len(prop.Enum) > 0 {
+			sig.WriteString("=enum(" + strings.Join(prop.Enum, ",") + ")")
+		}
+		if prop.Required {
+			sig.WriteString("!required")
+		}
+		if prop.Slice {
+			sig.WriteString("!slice")
+		}
+		if prop.Map {
+			sig.WriteString("!map")
+		}
+		if len(prop.Properties) > 0 {
+			sig.WriteString("{" + canonicalSignature(prop.Properties) + "}")
+		}
+		sig.WriteByte(';')
+	}
+	return sig.String()
+}
+
+// structBlock is one top-level `type X ...` declaration (plus any const
+// block immediately following it, e.g. an enum) sliced out of a combined
+// StructDefinitions blob.
+type structBlock struct {
+	Name   string
+	Source string
+}
+
+var topLevelDeclPattern = regexp.MustCompile(`(?m)^type (\w+) `)
+
+// splitStructBlocks slices a combined StructDefinitions blob (as produced by
+// generateStructDefinitions) into one block per top-level type declaration,
+// for Config.SplitFiles / Config.NestedPackage.
+func splitStructBlocks(structDefinitions string) []structBlock {
+	matches := topLevelDeclPattern.FindAllStringSubmatchIndex(structDefinitions, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	blocks := make([]structBlock, 0, len(matches))
+	for i, m := range matches {
+		start := m[0]
+		end := len(structDefinitions)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		blocks = append(blocks, structBlock{
+			Name:   structDefinitions[m[2]:m[3]],
+			Source: strings.TrimRight(structDefinitions[start:end], "\n") + "\n",
+		})
+	}
+	return blocks
+}
+
+// qualifyNestedReferences rewrites every "*Name", "[]Name", "[]*Name" or bare
+// "Name" reference to a struct that moved into nestedPackage, across every
+// block, into its nestedPackage-qualified form - except within the block that
+// declares Name itself. A bare reference (no "*") occurs for a required
+// nested field (see nestedFieldType) or a slice-of-objects field. Like the
+// rest of this package's generated output, the caller is responsible for
+// importing nestedPackage wherever the qualified reference ends up (compare
+// the pre-existing, equally unmanaged *time.Time / *GeoPoint references).
+func qualifyNestedReferences(blocks []structBlock, nestedNames []string, nestedPackage string) {
+	ordered := append([]string(nil), nestedNames...)
+	sort.Slice(ordered, func(i, j int) bool { return len(ordered[i]) > len(ordered[j]) })
+
+	for i := range blocks {
+		for _, name := range ordered {
+			if blocks[i].Name == name {
+				continue
+			}
+			// only match in type position (after a space or "]"), so a
+			// field name that happens to equal Name is left alone
+			re := regexp.MustCompile(`([ \]])(\[\]\*?|\*)?` + regexp.QuoteMeta(name) + `\b`)
+			blocks[i].Source = re.ReplaceAllString(blocks[i].Source, "${1}${2}"+nestedPackage+"."+name)
+		}
+	}
+}
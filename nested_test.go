@@ -0,0 +1,89 @@
+package elasticsearch2go
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestQualifyNestedReferences(t *testing.T) {
+	// Field name and moved-type name differ (User vs RootUser), matching how
+	// generateStruct actually names nested structs (structName +
+	// PascalCase(fieldName)).
+	cases := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"pointer", "type Root struct {\n\tUser *RootUser `json:\"user\"`\n}\n", "type Root struct {\n\tUser *nested.RootUser `json:\"user\"`\n}\n"},
+		{"bare (required)", "type Root struct {\n\tUser RootUser `json:\"user\"`\n}\n", "type Root struct {\n\tUser nested.RootUser `json:\"user\"`\n}\n"},
+		{"slice", "type Root struct {\n\tUsers []RootUser `json:\"users\"`\n}\n", "type Root struct {\n\tUsers []nested.RootUser `json:\"users\"`\n}\n"},
+		{"slice of pointers", "type Root struct {\n\tUsers []*RootUser `json:\"users\"`\n}\n", "type Root struct {\n\tUsers []*nested.RootUser `json:\"users\"`\n}\n"},
+		{"not a reference to a moved type", "type Root struct {\n\tSuperUser *SuperRootUser `json:\"superUser\"`\n}\n", "type Root struct {\n\tSuperUser *SuperRootUser `json:\"superUser\"`\n}\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			blocks := []structBlock{{Name: "Root", Source: c.src}}
+			qualifyNestedReferences(blocks, []string{"RootUser"}, "nested")
+			if blocks[0].Source != c.want {
+				t.Errorf("got:\n%s\nwant:\n%s", blocks[0].Source, c.want)
+			}
+		})
+	}
+}
+
+// TestGenerateSplitFilesNestedPackageRequiredField reproduces the
+// --split-files/--nested-package combination with a required nested object
+// field: the required field is rendered without a leading "*" (see
+// nestedFieldType), so the top-level file must still qualify it with the
+// nested package even though qualifyNestedReferences' regex used to only
+// match pointer-prefixed references.
+func TestGenerateSplitFilesNestedPackageRequiredField(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["user"],
+		"properties": {
+			"user": {
+				"type": "object",
+				"properties": {
+					"id": {"type": "string"}
+				}
+			}
+		}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	files, err := gen.Generate(context.Background(), bytes.NewReader(schema), Config{
+		PackageName:   "model",
+		StructName:    "Root",
+		SchemaFormat:  SchemaFormatJSONSchema,
+		SplitFiles:    true,
+		NestedPackage: "nested",
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	var rootSrc string
+	for _, f := range files {
+		if f.StructName == "Root" {
+			rootSrc = string(f.Source)
+		}
+	}
+	if rootSrc == "" {
+		t.Fatalf("no generated file for Root among %d files", len(files))
+	}
+
+	if !strings.Contains(rootSrc, "User nested.RootUser") {
+		t.Errorf("required nested field was not qualified with the nested package: got:\n%s", rootSrc)
+	}
+	if strings.Contains(rootSrc, "User RootUser") {
+		t.Errorf("required nested field left referencing the unqualified, undefined type RootUser: got:\n%s", rootSrc)
+	}
+}
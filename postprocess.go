@@ -0,0 +1,50 @@
+package elasticsearch2go
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+)
+
+// formatSource runs generated Go source through go/format, matching what
+// gofmt would do to a hand-written file; imports are not added or removed
+// (unlike goimports). Templates are easy to get
+// subtly wrong (stray blank lines, misaligned struct tags); this keeps
+// generated output indistinguishable from a formatted file on disk.
+func formatSource(src []byte) ([]byte, error) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("generated source is not valid Go: %w", err)
+	}
+	return formatted, nil
+}
+
+// verifySource parses and type-checks src as a standalone file, to catch
+// broken templates or missing imports (e.g. a custom --type-mapping entry
+// that maps to time.Time without the caller ever importing "time") before
+// the file is ever written to disk or committed.
+func verifySource(src []byte) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.AllErrors)
+	if err != nil {
+		return fmt.Errorf("generated source does not parse: %w", err)
+	}
+
+	var typeErrors []error
+	conf := types.Config{
+		Importer: importer.Default(),
+		Error:    func(err error) { typeErrors = append(typeErrors, err) },
+	}
+	if _, err := conf.Check(file.Name.Name, fset, []*ast.File{file}, nil); err != nil {
+		typeErrors = append(typeErrors, err)
+	}
+	if len(typeErrors) > 0 {
+		return fmt.Errorf("generated source does not compile: %w", typeErrors[0])
+	}
+
+	return nil
+}
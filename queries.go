@@ -0,0 +1,170 @@
+package elasticsearch2go
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// QueryField describes one addressable field path within a generated struct:
+// its dotted Elasticsearch JSON path (e.g. "user.address.city") and the name
+// of the Go constant holding that path (e.g. "OrderFieldUserAddressCity").
+type QueryField struct {
+	JSONPath  string
+	ConstName string
+}
+
+// queryHelpersTemplate is rendered once per struct. Every symbol it emits
+// carries StructName in its name (field consts start with it, query-builder
+// funcs are New<StructName>...) so
+// that --gen-queries stays safe when several generated structs, each with its
+// own _queries.go, land in the same package (e.g. via --config).
+const queryHelpersTemplate = `package {{.PackageName}}
+
+// Field path constants for {{.StructName}}, generated from its Elasticsearch
+// mapping. Use these instead of raw strings when building queries so that a
+// renamed or removed field is caught at compile time.
+const (
+{{- range .Fields}}
+	{{.ConstName}} = "{{.JSONPath}}"
+{{- end}}
+)
+
+// New{{.StructName}}TermQuery builds an Elasticsearch term query body matching field to value.
+func New{{.StructName}}TermQuery(field string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"term": map[string]interface{}{
+			field: value,
+		},
+	}
+}
+
+// New{{.StructName}}RangeQuery builds an Elasticsearch range query body for field using the
+// given range operators, e.g. map[string]interface{}{"gte": 10, "lte": 20}.
+func New{{.StructName}}RangeQuery(field string, ranges map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"range": map[string]interface{}{
+			field: ranges,
+		},
+	}
+}
+
+// New{{.StructName}}BoolQuery builds an Elasticsearch bool query body from its must/should/
+// must_not/filter clauses. Empty clauses are omitted.
+func New{{.StructName}}BoolQuery(must, should, mustNot, filter []map[string]interface{}) map[string]interface{} {
+	boolQuery := map[string]interface{}{}
+	if len(must) > 0 {
+		boolQuery["must"] = must
+	}
+	if len(should) > 0 {
+		boolQuery["should"] = should
+	}
+	if len(mustNot) > 0 {
+		boolQuery["must_not"] = mustNot
+	}
+	if len(filter) > 0 {
+		boolQuery["filter"] = filter
+	}
+	return map[string]interface{}{"bool": boolQuery}
+}
+`
+
+type queryHelpersData struct {
+	PackageName string
+	StructName  string
+	Fields      []QueryField
+}
+
+// collectQueryFields walks properties depth-first, recording a QueryField for
+// every field (including intermediate object/nested fields), skipping
+// anything in g.skipFields the same way generateStruct does. Properties are
+// visited in sorted key order so the emitted const block is deterministic
+// across runs, independent of Go's randomized map iteration order.
+func (g *Generator) collectQueryFields(structName string, properties map[string]Property, prefix string) []QueryField {
+	fields := make([]QueryField, 0, len(properties))
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop := properties[name]
+		if _, skip := g.skipFields[name]; skip {
+			continue
+		}
+
+		jsonPath := name
+		if prefix != "" {
+			jsonPath = prefix + "." + name
+		}
+
+		fields = append(fields, QueryField{
+			JSONPath:  jsonPath,
+			ConstName: structName + "Field" + queryFieldConstSuffix(jsonPath),
+		})
+
+		if (prop.Type == "object" || prop.Type == "nested") && !prop.Map {
+			// a Map property's keys are dynamic, so there's no fixed
+			// sub-path to record a QueryField for
+			fields = append(fields, g.collectQueryFields(structName, prop.Properties, jsonPath)...)
+		}
+	}
+
+	return fields
+}
+
+func queryFieldConstSuffix(jsonPath string) string {
+	parts := strings.Split(jsonPath, ".")
+	for i, part := range parts {
+		parts[i] = toPascalCase(part)
+	}
+	return strings.Join(parts, "")
+}
+
+// generateQueryHelpers renders the field-path constants and query-builder
+// helper functions for cfg.StructName's mapping.
+func (g *Generator) generateQueryHelpers(cfg Config, properties map[string]Property) (GeneratedFile, error) {
+	tmpl, err := template.New("queryHelpers").Parse(queryHelpersTemplate)
+	if err != nil {
+		return GeneratedFile{}, fmt.Errorf("error parsing query helpers template: %w", err)
+	}
+
+	data := queryHelpersData{
+		PackageName: cfg.PackageName,
+		StructName:  cfg.StructName,
+		Fields:      g.collectQueryFields(cfg.StructName, properties, ""),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return GeneratedFile{}, fmt.Errorf("error executing query helpers template: %w", err)
+	}
+
+	source := buf.Bytes()
+	if cfg.Format || cfg.Verify {
+		formatted, err := formatSource(source)
+		if err != nil {
+			return GeneratedFile{}, err
+		}
+		source = formatted
+	}
+	if cfg.Verify {
+		if err := verifySource(source); err != nil {
+			return GeneratedFile{}, err
+		}
+	}
+
+	return GeneratedFile{
+		Name:       queryHelpersFileName(cfg.StructName),
+		StructName: cfg.StructName,
+		Source:     source,
+	}, nil
+}
+
+func queryHelpersFileName(structName string) string {
+	return toSnakeCase(structName) + "_queries.go"
+}
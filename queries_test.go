@@ -0,0 +1,70 @@
+package elasticsearch2go
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+// TestGenerateQueryHelpersMultiStructNoCollision guards against the
+// --gen-queries files from two different structs sharing a package (the
+// --config multi-index path) redeclaring the same const/func names, which
+// happens whenever the structs share a field name (e.g. "id").
+func TestGenerateQueryHelpersMultiStructNoCollision(t *testing.T) {
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	properties := map[string]Property{
+		"id":   {Type: "keyword"},
+		"name": {Type: "text"},
+	}
+
+	order, err := gen.generateQueryHelpers(Config{PackageName: "searchmodel", StructName: "Order"}, properties)
+	if err != nil {
+		t.Fatalf("generateQueryHelpers(Order): %v", err)
+	}
+	product, err := gen.generateQueryHelpers(Config{PackageName: "searchmodel", StructName: "Product"}, properties)
+	if err != nil {
+		t.Fatalf("generateQueryHelpers(Product): %v", err)
+	}
+
+	seen := map[string]bool{}
+	for _, src := range [][]byte{order.Source, product.Source} {
+		for _, name := range topLevelNames(t, src) {
+			if seen[name] {
+				t.Fatalf("top-level identifier %q declared in both Order's and Product's query helper files", name)
+			}
+			seen[name] = true
+		}
+	}
+}
+
+func topLevelNames(t *testing.T, src []byte) []string {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.AllErrors)
+	if err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+
+	var names []string
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			names = append(names, d.Name.Name)
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if vs, ok := spec.(*ast.ValueSpec); ok {
+					for _, n := range vs.Names {
+						names = append(names, n.Name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
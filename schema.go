@@ -0,0 +1,254 @@
+package elasticsearch2go
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// SchemaFormat selects which SchemaLoader Generate uses to turn raw schema
+// bytes into the common Mappings IR. The zero value is SchemaFormatElasticsearch.
+type SchemaFormat string
+
+const (
+	SchemaFormatElasticsearch SchemaFormat = "es"
+	SchemaFormatJSONSchema    SchemaFormat = "jsonschema"
+	SchemaFormatOpenAPI       SchemaFormat = "openapi"
+)
+
+// SchemaLoader parses a raw schema document into the Mappings IR that
+// generateStruct already knows how to turn into Go structs. structName is
+// only consulted by loaders (OpenAPI) whose documents describe more than one
+// schema and need it to pick one.
+type SchemaLoader interface {
+	Load(data []byte, structName string) (Mappings, error)
+}
+
+func loaderForFormat(format SchemaFormat) (SchemaLoader, error) {
+	switch format {
+	case "", SchemaFormatElasticsearch:
+		return elasticsearchLoader{}, nil
+	case SchemaFormatJSONSchema:
+		return jsonSchemaLoader{}, nil
+	case SchemaFormatOpenAPI:
+		return openAPILoader{}, nil
+	default:
+		return nil, fmt.Errorf("unknown schema format %q (want %q, %q or %q)", format, SchemaFormatElasticsearch, SchemaFormatJSONSchema, SchemaFormatOpenAPI)
+	}
+}
+
+// elasticsearchLoader is the original, default behavior: an Elasticsearch
+// mapping document (`{"mappings": {"properties": {...}}}`).
+type elasticsearchLoader struct{}
+
+func (elasticsearchLoader) Load(data []byte, _ string) (Mappings, error) {
+	var esMapping ElasticsearchMapping
+	if err := json.Unmarshal(data, &esMapping); err != nil {
+		return Mappings{}, fmt.Errorf("error unmarshalling Elasticsearch mapping JSON: %w", err)
+	}
+	return esMapping.Mappings, nil
+}
+
+// jsonSchemaNode is the wire format for a JSON Schema (draft 2020-12) node.
+type jsonSchemaNode struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Properties           map[string]jsonSchemaNode `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Items                *jsonSchemaNode           `json:"items,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	AdditionalProperties *jsonSchemaNode           `json:"additionalProperties,omitempty"`
+	OneOf                []jsonSchemaNode          `json:"oneOf,omitempty"`
+	AnyOf                []jsonSchemaNode          `json:"anyOf,omitempty"`
+	Defs                 map[string]jsonSchemaNode `json:"$defs,omitempty"`
+	Definitions          map[string]jsonSchemaNode `json:"definitions,omitempty"` // draft-07 compat
+}
+
+type jsonSchemaLoader struct{}
+
+func (jsonSchemaLoader) Load(data []byte, _ string) (Mappings, error) {
+	var root jsonSchemaNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		return Mappings{}, fmt.Errorf("error unmarshalling JSON Schema: %w", err)
+	}
+
+	defs := root.Defs
+	if defs == nil {
+		defs = root.Definitions
+	}
+
+	// "$ref": "#" refers to the document root; the root is marked as seen
+	// from the start, so such a reference is reported as cyclic
+	withRoot := make(map[string]jsonSchemaNode, len(defs)+1)
+	for name, def := range defs {
+		withRoot[name] = def
+	}
+	withRoot["#"] = root
+
+	prop := convertJSONSchemaNode(root, withRoot, map[string]bool{"#": true})
+	return Mappings{Properties: prop.Properties}, nil
+}
+
+// openAPILoader reads OpenAPI 3 component schemas, which are structurally
+// JSON Schema with $refs of the form "#/components/schemas/Foo" instead of
+// "#/$defs/Foo". structName selects which component to generate when data is
+// a full OpenAPI document with more than one schema.
+type openAPILoader struct{}
+
+type openAPIDoc struct {
+	Components struct {
+		Schemas map[string]jsonSchemaNode `json:"schemas"`
+	} `json:"components"`
+}
+
+func (openAPILoader) Load(data []byte, structName string) (Mappings, error) {
+	var doc openAPIDoc
+	if err := json.Unmarshal(data, &doc); err == nil && len(doc.Components.Schemas) > 0 {
+		target, ok := doc.Components.Schemas[structName]
+		if !ok {
+			if len(doc.Components.Schemas) != 1 {
+				return Mappings{}, fmt.Errorf("openapi: no component schema named %q (document declares %d schemas)", structName, len(doc.Components.Schemas))
+			}
+			for _, only := range doc.Components.Schemas {
+				target = only
+			}
+		}
+
+		prop := convertJSONSchemaNode(target, doc.Components.Schemas, map[string]bool{})
+		return Mappings{Properties: prop.Properties}, nil
+	}
+
+	// Not a full OpenAPI document: treat data as a single bare schema object.
+	var node jsonSchemaNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		return Mappings{}, fmt.Errorf("error unmarshalling OpenAPI schema: %w", err)
+	}
+
+	prop := convertJSONSchemaNode(node, nil, map[string]bool{})
+	return Mappings{Properties: prop.Properties}, nil
+}
+
+// convertJSONSchemaNode turns a JSON Schema / OpenAPI schema node into our
+// common Property IR, resolving $ref against defs, degrading oneOf/anyOf to
+// interface{} (Go structs can't express a structural union), and recording
+// enum/additionalProperties so generateStruct can emit a typed const block or
+// a map[string]T field respectively.
+func convertJSONSchemaNode(node jsonSchemaNode, defs map[string]jsonSchemaNode, seen map[string]bool) Property {
+	if node.Ref != "" {
+		name := refName(node.Ref)
+		if seen[name] {
+			log.Printf("elasticsearch2go: cyclic $ref %q, degrading to interface{}", node.Ref)
+			return Property{GoType: "interface{}"}
+		}
+		target, ok := defs[name]
+		if !ok {
+			log.Printf("elasticsearch2go: unresolved $ref %q, degrading to interface{}", node.Ref)
+			return Property{GoType: "interface{}"}
+		}
+		nextSeen := make(map[string]bool, len(seen)+1)
+		for k := range seen {
+			nextSeen[k] = true
+		}
+		nextSeen[name] = true
+		return convertJSONSchemaNode(target, defs, nextSeen)
+	}
+
+	if len(node.OneOf) > 0 || len(node.AnyOf) > 0 {
+		log.Printf("elasticsearch2go: oneOf/anyOf is not representable as a single Go type, degrading to interface{}")
+		return Property{GoType: "interface{}"}
+	}
+
+	if len(node.Enum) > 0 {
+		return Property{Enum: node.Enum}
+	}
+
+	switch node.Type {
+	case "object":
+		if len(node.Properties) == 0 && node.AdditionalProperties != nil {
+			value := convertJSONSchemaNode(*node.AdditionalProperties, defs, seen)
+			if value.Type == "object" && len(value.Properties) > 0 {
+				// the map's value is itself an object with concrete fields:
+				// keep its shape so generateStruct can emit a named struct
+				// for it instead of collapsing to map[string]interface{}
+				return Property{Type: "object", Map: true, Properties: value.Properties}
+			}
+			return Property{Type: "object", Map: true, MapValueGoType: mapValueGoType(value)}
+		}
+
+		props := make(map[string]Property, len(node.Properties))
+		for name, child := range node.Properties {
+			props[name] = convertJSONSchemaNode(child, defs, seen)
+		}
+		for _, name := range node.Required {
+			if prop, ok := props[name]; ok {
+				prop.Required = true
+				prop.GoType = strings.TrimPrefix(prop.GoType, "*")
+				props[name] = prop
+			}
+		}
+		return Property{Type: "object", Properties: props}
+
+	case "array":
+		if node.Items != nil && node.Items.Ref != "" {
+			// resolve a $ref'd item schema first, so that a referenced
+			// object is still rendered as a slice of a nested struct
+			item := convertJSONSchemaNode(*node.Items, defs, seen)
+			if item.Type == "object" && !item.Map {
+				item.Type = "nested"
+				item.Slice = true
+				return item
+			}
+			if item.GoType != "" {
+				return Property{GoType: "[]" + strings.TrimPrefix(item.GoType, "*")}
+			}
+			return Property{GoType: "[]interface{}"}
+		}
+		if node.Items != nil && node.Items.Type == "object" {
+			item := convertJSONSchemaNode(*node.Items, defs, seen)
+			item.Type = "nested" // reuse the existing nested-struct code path
+			item.Slice = true    // ...but render the field as []Name, not *Name
+			return item
+		}
+		itemType := "interface{}"
+		if node.Items != nil {
+			itemType = strings.TrimPrefix(jsonSchemaPrimitiveGoType(node.Items.Type), "*")
+		}
+		return Property{GoType: "[]" + itemType}
+
+	default:
+		return Property{GoType: jsonSchemaPrimitiveGoType(node.Type)}
+	}
+}
+
+func jsonSchemaPrimitiveGoType(t string) string {
+	switch t {
+	case "string":
+		return "*string"
+	case "integer":
+		return "*int64"
+	case "number":
+		return "*float64"
+	case "boolean":
+		return "bool"
+	default:
+		return "interface{}"
+	}
+}
+
+func mapValueGoType(p Property) string {
+	if p.GoType != "" {
+		return p.GoType
+	}
+	if p.Type == "object" {
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+func refName(ref string) string {
+	if idx := strings.LastIndex(ref, "/"); idx != -1 {
+		return ref[idx+1:]
+	}
+	return ref
+}
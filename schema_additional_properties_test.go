@@ -0,0 +1,90 @@
+package elasticsearch2go
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestGenerateJSONSchemaAdditionalPropertiesNoFixedShape checks the existing,
+// still-supported case: additionalProperties with no concrete fields (a
+// scalar or bare object value) collapses to map[string]T for a primitive/
+// interface{} T.
+func TestGenerateJSONSchemaAdditionalPropertiesNoFixedShape(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"scores": {
+				"type": "object",
+				"additionalProperties": {"type": "integer"}
+			}
+		}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	files, err := gen.Generate(context.Background(), bytes.NewReader(schema), Config{
+		PackageName:  "model",
+		StructName:   "Person",
+		SchemaFormat: SchemaFormatJSONSchema,
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	src := string(files[0].Source)
+
+	if !strings.Contains(src, "Scores map[string]*int64") {
+		t.Errorf("want Scores map[string]*int64, got:\n%s", src)
+	}
+}
+
+// TestGenerateJSONSchemaAdditionalPropertiesStructValue checks that
+// additionalProperties whose value is an object with concrete fields
+// generates a named struct for that shape and references it as
+// map[string]StructName, instead of collapsing to map[string]interface{}.
+func TestGenerateJSONSchemaAdditionalPropertiesStructValue(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"attributes": {
+				"type": "object",
+				"additionalProperties": {
+					"type": "object",
+					"properties": {
+						"value": {"type": "string"},
+						"confidence": {"type": "number"}
+					}
+				}
+			}
+		}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	files, err := gen.Generate(context.Background(), bytes.NewReader(schema), Config{
+		PackageName:  "model",
+		StructName:   "Person",
+		SchemaFormat: SchemaFormatJSONSchema,
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	src := string(files[0].Source)
+
+	if !strings.Contains(src, "Attributes map[string]PersonAttributes") {
+		t.Errorf("additionalProperties with concrete fields should map to a named struct, got:\n%s", src)
+	}
+	if !strings.Contains(src, "type PersonAttributes struct") {
+		t.Errorf("expected a generated struct for the map's value shape, got:\n%s", src)
+	}
+	if strings.Contains(src, "map[string]interface{}") {
+		t.Errorf("additionalProperties with concrete fields should not collapse to map[string]interface{}, got:\n%s", src)
+	}
+}
@@ -0,0 +1,269 @@
+package elasticsearch2go
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestJSONSchemaLoaderEnum checks that a JSON Schema "enum" field generates
+// a named string type and a typed const block for its values.
+func TestJSONSchemaLoaderEnum(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"status": {"type": "string", "enum": ["active", "in-progress", "done"]}
+		}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	files, err := gen.Generate(context.Background(), bytes.NewReader(schema), Config{
+		PackageName:  "model",
+		StructName:   "Task",
+		SchemaFormat: SchemaFormatJSONSchema,
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	src := string(files[0].Source)
+
+	for _, want := range []string{
+		"Status TaskStatus",
+		"type TaskStatus string",
+		`TaskStatusActive TaskStatus = "active"`,
+		`TaskStatusInProgress TaskStatus = "in-progress"`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("want %q in generated source:\n%s", want, src)
+		}
+	}
+}
+
+// TestJSONSchemaLoaderRefResolution checks that a "$ref" into "$defs" is
+// resolved to the referenced schema's shape, producing a nested struct.
+func TestJSONSchemaLoaderRefResolution(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"author": {"$ref": "#/$defs/Author"}
+		},
+		"$defs": {
+			"Author": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"}
+				}
+			}
+		}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	files, err := gen.Generate(context.Background(), bytes.NewReader(schema), Config{
+		PackageName:  "model",
+		StructName:   "Book",
+		SchemaFormat: SchemaFormatJSONSchema,
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	src := string(files[0].Source)
+
+	for _, want := range []string{"Author *BookAuthor", "type BookAuthor struct", "Name *string"} {
+		if !strings.Contains(src, want) {
+			t.Errorf("want %q in generated source:\n%s", want, src)
+		}
+	}
+}
+
+// TestJSONSchemaLoaderCyclicRefDegradesToInterface checks that a self-
+// referencing $ref (directly or transitively) degrades to interface{}
+// instead of recursing forever.
+func TestJSONSchemaLoaderCyclicRefDegradesToInterface(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"children": {
+				"type": "array",
+				"items": {"$ref": "#/$defs/Node"}
+			}
+		},
+		"$defs": {
+			"Node": {"$ref": "#"}
+		}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	files, err := gen.Generate(context.Background(), bytes.NewReader(schema), Config{
+		PackageName:  "model",
+		StructName:   "Node",
+		SchemaFormat: SchemaFormatJSONSchema,
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	src := string(files[0].Source)
+
+	if !strings.Contains(src, "Children []interface{}") && !strings.Contains(src, "Children interface{}") {
+		t.Errorf("cyclic $ref should degrade to interface{}, got:\n%s", src)
+	}
+}
+
+// TestJSONSchemaLoaderOneOfAnyOfDegradesToInterface checks that oneOf/anyOf
+// fields - which can't be expressed as a single Go struct type - degrade to
+// interface{} instead of erroring out or picking an arbitrary branch.
+func TestJSONSchemaLoaderOneOfAnyOfDegradesToInterface(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"value": {"oneOf": [{"type": "string"}, {"type": "integer"}]},
+			"tag": {"anyOf": [{"type": "string"}, {"type": "integer"}]}
+		}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	files, err := gen.Generate(context.Background(), bytes.NewReader(schema), Config{
+		PackageName:  "model",
+		StructName:   "Event",
+		SchemaFormat: SchemaFormatJSONSchema,
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	src := string(files[0].Source)
+
+	for _, want := range []string{"Value interface{}", "Tag interface{}"} {
+		if !strings.Contains(src, want) {
+			t.Errorf("want %q in generated source:\n%s", want, src)
+		}
+	}
+}
+
+// TestOpenAPILoaderComponentSelection checks that, given a full OpenAPI
+// document with multiple component schemas, the loader picks the one named
+// by structName and errors out if it's missing and there's more than one to
+// choose from.
+func TestOpenAPILoaderComponentSelection(t *testing.T) {
+	doc := []byte(`{
+		"components": {
+			"schemas": {
+				"User": {
+					"type": "object",
+					"properties": {"id": {"type": "string"}}
+				},
+				"Order": {
+					"type": "object",
+					"properties": {"total": {"type": "number"}}
+				}
+			}
+		}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	files, err := gen.Generate(context.Background(), bytes.NewReader(doc), Config{
+		PackageName:  "model",
+		StructName:   "Order",
+		SchemaFormat: SchemaFormatOpenAPI,
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	src := string(files[0].Source)
+	if !strings.Contains(src, "Total *float64") {
+		t.Errorf("expected the Order component's fields, got:\n%s", src)
+	}
+	if strings.Contains(src, "Id ") {
+		t.Errorf("should not have picked the User component, got:\n%s", src)
+	}
+
+	_, err = gen.Generate(context.Background(), bytes.NewReader(doc), Config{
+		PackageName:  "model",
+		StructName:   "Invoice",
+		SchemaFormat: SchemaFormatOpenAPI,
+	})
+	if err == nil {
+		t.Error("expected an error selecting an unknown component out of a multi-schema document, got nil")
+	}
+}
+
+// TestOpenAPILoaderSingleSchemaDocument checks that, when a components
+// document declares exactly one schema, it's used even if its name doesn't
+// match structName.
+func TestOpenAPILoaderSingleSchemaDocument(t *testing.T) {
+	doc := []byte(`{
+		"components": {
+			"schemas": {
+				"User": {
+					"type": "object",
+					"properties": {"id": {"type": "string"}}
+				}
+			}
+		}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	files, err := gen.Generate(context.Background(), bytes.NewReader(doc), Config{
+		PackageName:  "model",
+		StructName:   "Account",
+		SchemaFormat: SchemaFormatOpenAPI,
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	src := string(files[0].Source)
+	if !strings.Contains(src, "Id *string") {
+		t.Errorf("expected the lone component's fields, got:\n%s", src)
+	}
+}
+
+// TestOpenAPILoaderBareSchema checks that a document with no
+// components.schemas envelope is treated as a single bare schema object.
+func TestOpenAPILoaderBareSchema(t *testing.T) {
+	doc := []byte(`{
+		"type": "object",
+		"properties": {"id": {"type": "string"}}
+	}`)
+
+	gen, err := NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("NewGenerator: %v", err)
+	}
+
+	files, err := gen.Generate(context.Background(), bytes.NewReader(doc), Config{
+		PackageName:  "model",
+		StructName:   "Widget",
+		SchemaFormat: SchemaFormatOpenAPI,
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	src := string(files[0].Source)
+	if !strings.Contains(src, "Id *string") {
+		t.Errorf("expected the bare schema's fields, got:\n%s", src)
+	}
+}